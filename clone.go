@@ -0,0 +1,9 @@
+package mirror
+
+// Clone returns a deep copy of src. Unlike DeepCopyInto, it allocates the target itself, so callers
+// don't need to construct a zero value and thread it through DeepCopyInto/From by hand.
+func Clone[T any](src T) T {
+	var out T
+	DeepCopyInto(&out).From(&src)
+	return out
+}
@@ -0,0 +1,292 @@
+// Command mirror-gen scans a Go package for struct types marked with a "+mirror:generate"
+// doc comment and emits a mirror_generated.go file containing a hand-rolled DeepCopyInto method
+// for each one. Each generated method is registered with mirror.RegisterGlobalDeepCopyFunc from
+// an init() function, so that mirror.DeepCopy bypasses reflection for these types.
+//
+// Usage:
+//
+//	mirror-gen -dir ./path/to/package
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const generateMarker = "+mirror:generate"
+
+// structType describes a struct type selected for generation.
+type structType struct {
+	name   string
+	fields []*ast.Field
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package to scan")
+	output := flag.String("output", "mirror_generated.go", "name of the generated file, relative to -dir")
+	flag.Parse()
+
+	if err := run(*dir, *output); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(dir, output string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("mirror-gen: parsing %s: %w", dir, err)
+	}
+
+	for name, pkg := range pkgs {
+		types := collectStructTypes(pkg)
+		if len(types) == 0 {
+			continue
+		}
+
+		src, err := render(name, types)
+		if err != nil {
+			return fmt.Errorf("mirror-gen: rendering %s: %w", name, err)
+		}
+
+		path := filepath.Join(dir, output)
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return fmt.Errorf("mirror-gen: writing %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// collectStructTypes walks every file in pkg and returns the struct types whose doc comment
+// contains the generateMarker.
+func collectStructTypes(pkg *ast.Package) []structType {
+	var types []structType
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				doc := ts.Doc
+				if doc == nil {
+					doc = gen.Doc
+				}
+				if doc == nil || !strings.Contains(doc.Text(), generateMarker) {
+					continue
+				}
+
+				types = append(types, structType{name: ts.Name.Name, fields: st.Fields.List})
+			}
+		}
+	}
+
+	return types
+}
+
+// render produces the source of the generated file for the given package and types.
+func render(pkgName string, types []structType) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by mirror-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "import \"github.com/sbstp/mirror\"\n\n")
+
+	fmt.Fprintf(&buf, "func init() {\n")
+	for _, t := range types {
+		fmt.Fprintf(&buf, "\tif err := mirror.RegisterGlobalDeepCopyFunc(func(in %s, out *%s) error {\n", t.name, t.name)
+		fmt.Fprintf(&buf, "\t\tin.DeepCopyInto(out)\n")
+		fmt.Fprintf(&buf, "\t\treturn nil\n")
+		fmt.Fprintf(&buf, "\t}); err != nil {\n")
+		fmt.Fprintf(&buf, "\t\tpanic(err)\n")
+		fmt.Fprintf(&buf, "\t}\n")
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+
+	generated := make(map[string]bool, len(types))
+	for _, t := range types {
+		generated[t.name] = true
+	}
+
+	for _, t := range types {
+		writeDeepCopyInto(&buf, t, generated)
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// writeDeepCopyInto emits a DeepCopyInto method for t. Pointer, slice, map and array fields
+// (including a fixed-size array of pointers/slices/maps, which *out = *in alone would leave
+// aliased) get an explicit copy via writeElemAssign; every other field is copied with a plain
+// assignment, since *out = *in already handles it. A field (or a pointer/slice/map/array's
+// element type) whose own named type was also selected for generation calls into that type's
+// DeepCopyInto instead of being shallow-assigned; a struct-typed field whose type was not
+// selected for generation is still only shallow-copied by *out = *in, so mark every struct type
+// reachable from a generated type with +mirror:generate.
+func writeDeepCopyInto(buf *bytes.Buffer, t structType, generated map[string]bool) {
+	fmt.Fprintf(buf, "func (in *%s) DeepCopyInto(out *%s) {\n", t.name, t.name)
+	fmt.Fprintf(buf, "\t*out = *in\n")
+
+	for _, field := range t.fields {
+		for _, name := range fieldNames(field) {
+			writeFieldCopy(buf, name, field.Type, generated)
+		}
+	}
+
+	fmt.Fprintf(buf, "}\n\n")
+}
+
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		// Embedded field; Go names it after the type's identifier, pointer or not.
+		if ident, ok := identName(field.Type); ok {
+			return []string{ident}
+		}
+		return nil
+	}
+
+	names := make([]string, len(field.Names))
+	for i, n := range field.Names {
+		names[i] = n.Name
+	}
+	return names
+}
+
+// writeFieldCopy emits the copy for a single struct field, dispatching to writeElemAssign for any
+// shape that needs more than the struct-level *out = *in.
+func writeFieldCopy(buf *bytes.Buffer, name string, typ ast.Expr, generated map[string]bool) {
+	switch t := typ.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType:
+		writeElemAssign(buf, "out."+name, "in."+name, typ, generated, "\t")
+	case *ast.Ident:
+		if generated[t.Name] {
+			fmt.Fprintf(buf, "\tin.%s.DeepCopyInto(&out.%s)\n", name, name)
+		}
+		// Otherwise a plain value, already copied by the struct-level *out = *in.
+	default:
+		// Plain value, already copied by the struct-level *out = *in.
+	}
+}
+
+// writeElemAssign emits the code that deep-copies src into dst, where both are addressable Go
+// expressions (a field, a slice/array index, or a local variable) of type typ. It is the single
+// place that knows how to walk into a pointer, array, map or generated-struct shape, so slice
+// elements, map values, array elements and struct fields all get the same treatment instead of
+// each caller reimplementing a subset of it.
+func writeElemAssign(buf *bytes.Buffer, dst, src string, typ ast.Expr, generated map[string]bool, indent string) {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		fmt.Fprintf(buf, "%sif %s != nil {\n", indent, src)
+		fmt.Fprintf(buf, "%s\t%s = new(%s)\n", indent, dst, exprString(t.X))
+		if name, ok := identName(t.X); ok && generated[name] {
+			fmt.Fprintf(buf, "%s\t%s.DeepCopyInto(%s)\n", indent, src, dst)
+		} else {
+			fmt.Fprintf(buf, "%s\t*%s = *%s\n", indent, dst, src)
+		}
+		fmt.Fprintf(buf, "%s}\n", indent)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			// Fixed-size array: value-assign it (the struct-level *out = *in already did this for
+			// a top-level field, but nested arrays reached through a slice/map element need it too),
+			// then fix up any element that isn't a plain value in place.
+			fmt.Fprintf(buf, "%s%s = %s\n", indent, dst, src)
+			if elemNeedsWalk(t.Elt, generated) {
+				fmt.Fprintf(buf, "%sfor i := range %s {\n", indent, src)
+				writeElemAssign(buf, fmt.Sprintf("%s[i]", dst), fmt.Sprintf("%s[i]", src), t.Elt, generated, indent+"\t")
+				fmt.Fprintf(buf, "%s}\n", indent)
+			}
+			return
+		}
+		fmt.Fprintf(buf, "%sif %s != nil {\n", indent, src)
+		fmt.Fprintf(buf, "%s\t%s = make(%s, len(%s))\n", indent, dst, exprString(t), src)
+		if elemNeedsWalk(t.Elt, generated) {
+			fmt.Fprintf(buf, "%s\tfor i := range %s {\n", indent, src)
+			writeElemAssign(buf, fmt.Sprintf("%s[i]", dst), fmt.Sprintf("%s[i]", src), t.Elt, generated, indent+"\t\t")
+			fmt.Fprintf(buf, "%s\t}\n", indent)
+		} else {
+			fmt.Fprintf(buf, "%s\tcopy(%s, %s)\n", indent, dst, src)
+		}
+		fmt.Fprintf(buf, "%s}\n", indent)
+	case *ast.MapType:
+		fmt.Fprintf(buf, "%sif %s != nil {\n", indent, src)
+		fmt.Fprintf(buf, "%s\t%s = make(%s, len(%s))\n", indent, dst, exprString(t), src)
+		fmt.Fprintf(buf, "%s\tfor k, v := range %s {\n", indent, src)
+		if elemNeedsWalk(t.Value, generated) {
+			fmt.Fprintf(buf, "%s\t\tvar vout %s\n", indent, exprString(t.Value))
+			writeElemAssign(buf, "vout", "v", t.Value, generated, indent+"\t\t")
+			fmt.Fprintf(buf, "%s\t\t%s[k] = vout\n", indent, dst)
+		} else {
+			fmt.Fprintf(buf, "%s\t\t%s[k] = v\n", indent, dst)
+		}
+		fmt.Fprintf(buf, "%s\t}\n", indent)
+		fmt.Fprintf(buf, "%s}\n", indent)
+	case *ast.Ident:
+		if generated[t.Name] {
+			fmt.Fprintf(buf, "%s%s.DeepCopyInto(&%s)\n", indent, src, dst)
+		} else {
+			fmt.Fprintf(buf, "%s%s = %s\n", indent, dst, src)
+		}
+	default:
+		fmt.Fprintf(buf, "%s%s = %s\n", indent, dst, src)
+	}
+}
+
+// elemNeedsWalk reports whether a slice/map/array element of type typ shares references that a
+// plain copy/assignment would leave aliased: a pointer (even to a type mirror-gen never saw), a
+// nested slice/map/array, or a named type that was itself selected for generation.
+func elemNeedsWalk(typ ast.Expr, generated map[string]bool) bool {
+	switch t := typ.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType:
+		return true
+	case *ast.Ident:
+		return generated[t.Name]
+	default:
+		return false
+	}
+}
+
+// identName returns the identifier name of typ if it is a plain identifier or a pointer to one (a
+// type declared in this package, possibly embedded by pointer), and ok=false otherwise (for
+// example a qualified *ast.SelectorExpr for an imported type, which can never be in the generated
+// set).
+func identName(typ ast.Expr) (string, bool) {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return ident.Name, true
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%v", expr)
+	}
+	return buf.String()
+}
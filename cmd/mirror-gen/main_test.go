@@ -0,0 +1,139 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// parseStructs parses src as a standalone file and returns the +mirror:generate struct types in
+// it, reusing collectStructTypes the same way run does for a whole directory.
+func parseStructs(t *testing.T, src string) []structType {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parsing test source: %v", err)
+	}
+	pkg := &ast.Package{Name: file.Name.Name, Files: map[string]*ast.File{"test.go": file}}
+	return collectStructTypes(pkg)
+}
+
+func TestRenderEmbeddedPointerField(t *testing.T) {
+	src := `package sample
+
+// +mirror:generate
+type Inner struct {
+	Value int
+}
+
+// +mirror:generate
+type Outer struct {
+	*Inner
+}
+`
+	out, err := render("sample", parseStructs(t, src))
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "in.Inner.DeepCopyInto(out.Inner)")
+}
+
+func TestRenderFixedArrayOfPointers(t *testing.T) {
+	src := `package sample
+
+// +mirror:generate
+type Outer struct {
+	Nums [3]*int
+}
+`
+	out, err := render("sample", parseStructs(t, src))
+	assert.NoError(t, err)
+	s := string(out)
+	assert.Contains(t, s, "out.Nums = in.Nums")
+	assert.Contains(t, s, "for i := range in.Nums {")
+	assert.Contains(t, s, "out.Nums[i] = new(int)")
+	assert.Contains(t, s, "*out.Nums[i] = *in.Nums[i]")
+}
+
+func TestRenderSliceAndMapOfPointerToGeneratedType(t *testing.T) {
+	src := `package sample
+
+// +mirror:generate
+type Inner struct {
+	Value int
+}
+
+// +mirror:generate
+type Outer struct {
+	Items []*Inner
+	ByKey map[string]*Inner
+}
+`
+	out, err := render("sample", parseStructs(t, src))
+	assert.NoError(t, err)
+	s := string(out)
+	assert.Contains(t, s, "out.Items[i] = new(Inner)")
+	assert.Contains(t, s, "in.Items[i].DeepCopyInto(out.Items[i])")
+	assert.Contains(t, s, "vout = new(Inner)")
+	assert.Contains(t, s, "v.DeepCopyInto(vout)")
+}
+
+// TestRenderOutputTypeChecks goes one step past the string-matching tests above: render() only
+// runs its output through format.Source, which catches syntax errors but not a type error (e.g. a
+// wrong number of DeepCopyInto arguments, or assigning *int where *Inner is expected). Type-check
+// the generated file against the source package it was generated from to catch that class of bug.
+func TestRenderOutputTypeChecks(t *testing.T) {
+	src := `package sample
+
+// +mirror:generate
+type Inner struct {
+	Value int
+}
+
+// +mirror:generate
+type Outer struct {
+	*Inner
+	Nums  [3]*int
+	Items []*Inner
+	ByKey map[string]*Inner
+}
+`
+	fset := token.NewFileSet()
+	srcFile, err := parser.ParseFile(fset, "sample.go", src, parser.ParseComments)
+	assert.NoError(t, err)
+	pkg := &ast.Package{Name: srcFile.Name.Name, Files: map[string]*ast.File{"sample.go": srcFile}}
+
+	out, err := render("sample", collectStructTypes(pkg))
+	assert.NoError(t, err)
+
+	// Drop the init()/mirror import boilerplate: it registers against the real mirror package,
+	// which this standalone type-check has no module root to resolve. What's under test here is
+	// whether the DeepCopyInto bodies themselves type-check, not the registration glue.
+	body := stripInitAndImport(t, string(out))
+
+	genFile, err := parser.ParseFile(fset, "mirror_generated.go", body, 0)
+	assert.NoError(t, err)
+
+	conf := types.Config{Importer: importer.Default(), Error: func(err error) { t.Error(err) }}
+	_, err = conf.Check("sample", fset, []*ast.File{srcFile, genFile}, nil)
+	assert.NoError(t, err)
+}
+
+func stripInitAndImport(t *testing.T, src string) string {
+	t.Helper()
+	src = strings.Replace(src, "import \"github.com/sbstp/mirror\"\n\n", "", 1)
+	start := strings.Index(src, "func init() {")
+	if start == -1 {
+		t.Fatalf("generated source has no init() to strip:\n%s", src)
+	}
+	end := strings.Index(src[start:], "}\n\n")
+	if end == -1 {
+		t.Fatalf("generated source's init() has no closing brace:\n%s", src)
+	}
+	return src[:start] + src[start+end+len("}\n\n"):]
+}
@@ -0,0 +1,179 @@
+package mirror
+
+import "reflect"
+
+// equalConfig holds the options accumulated from a DeepEqual call's EqualOptions.
+type equalConfig struct {
+	ignoreZeroValues bool
+	ignoreUnexported bool
+	converters       map[converterKey]reflect.Value
+}
+
+// EqualOption configures a DeepEqual call.
+type EqualOption func(*equalConfig)
+
+// EqualIgnoreZeroValues makes DeepEqual treat a zero-value field as equal to anything, mirroring
+// DeepCopy.SetIgnoreZeroValues so that a "copy then compare" round-trip stays consistent: fields
+// a zero-value-ignoring copy would have left untouched are not reported as differences.
+func EqualIgnoreZeroValues(ignore bool) EqualOption {
+	return func(c *equalConfig) { c.ignoreZeroValues = ignore }
+}
+
+// EqualIgnoreUnexported makes DeepEqual skip unexported fields, mirroring DeepCopy.SetIgnoreUnexported.
+func EqualIgnoreUnexported(ignore bool) EqualOption {
+	return func(c *equalConfig) { c.ignoreUnexported = ignore }
+}
+
+// EqualConverters supplies d's registered converters (see DeepCopy.RegisterConverter) to DeepEqual.
+// DeepEqual is a free function with no DeepCopy instance of its own to consult, so without this
+// option a and b produced via a converter (whose types legitimately differ, e.g. time.Time and
+// string) would be reported unequal outright by the type check before any field is inspected.
+func EqualConverters(d *DeepCopy) EqualOption {
+	return func(c *equalConfig) { c.converters = d.converters }
+}
+
+// visitedPair tracks a (src pointer, dst pointer) combination already walked by performDeepEqual,
+// the same way DeepCopy.visited tracks source pointers already walked by performDeepCopy. Without
+// it, a cyclic object graph (see TestCircularReference) recurses forever.
+type visitedPair [2]uintptr
+
+// DeepEqual reports whether a and b are deeply equal, walking them the same way performDeepCopy
+// walks a copy. It honors the same knobs as DeepCopy (via EqualIgnoreZeroValues/EqualIgnoreUnexported),
+// per-field `mirror:"omitempty"` tags, and registered converters (via EqualConverters), so that
+// comparing the result of a DeepCopy.From call against its source behaves consistently with how
+// that copy was made.
+func DeepEqual(a, b interface{}, opts ...EqualOption) bool {
+	cfg := &equalConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Like FromE, make both sides addressable so unexported fields can be read.
+	av := makeAddressable(reflect.ValueOf(a))
+	bv := makeAddressable(reflect.ValueOf(b))
+	visited := make(map[visitedPair]bool)
+
+	return performDeepEqual(av, bv, cfg, visited)
+}
+
+func performDeepEqual(a, b reflect.Value, cfg *equalConfig, visited map[visitedPair]bool) bool {
+	// A converter is consulted at every recursion level, not just the top: a struct with one
+	// mismatched field compared alongside identical ones needs the field-level pair just as much
+	// as a call where the whole value was produced by a converter. Absent a converter for this
+	// exact pair, fall through to the Kind-based comparison below instead of failing outright,
+	// the same way performDeepCopy defers to its own kind switch when no converter matches a node.
+	if a.IsValid() && b.IsValid() && a.Type() != b.Type() {
+		if conv, ok := cfg.converters[converterKey{src: a.Type(), dst: b.Type()}]; ok {
+			out := conv.Call([]reflect.Value{a})
+			if !out[1].IsNil() {
+				return false
+			}
+			return performDeepEqual(reflect.ValueOf(out[0].Interface()), b, cfg, visited)
+		}
+		if a.Kind() != b.Kind() {
+			return false
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Invalid:
+		return !b.IsValid()
+	case reflect.Ptr:
+		if a.IsNil() != b.IsNil() {
+			return cfg.ignoreZeroValues
+		}
+		if a.IsNil() {
+			return true
+		}
+		pair := visitedPair{a.Pointer(), b.Pointer()}
+		if visited[pair] {
+			return true
+		}
+		visited[pair] = true
+		return performDeepEqual(a.Elem(), b.Elem(), cfg, visited)
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !performDeepEqual(a.Index(i), b.Index(i), cfg, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() {
+			return cfg.ignoreZeroValues
+		}
+		if a.IsNil() {
+			return true
+		}
+		pair := visitedPair{a.Pointer(), b.Pointer()}
+		if visited[pair] {
+			return true
+		}
+		visited[pair] = true
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !performDeepEqual(a.Index(i), b.Index(i), cfg, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() {
+			return cfg.ignoreZeroValues
+		}
+		if a.IsNil() {
+			return true
+		}
+		pair := visitedPair{a.Pointer(), b.Pointer()}
+		if visited[pair] {
+			return true
+		}
+		visited[pair] = true
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bval := b.MapIndex(iter.Key())
+			if !bval.IsValid() {
+				return false
+			}
+			if !performDeepEqual(makeAddressable(iter.Value()), makeAddressable(bval), cfg, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		strategies := fieldStrategiesFor(a.Type())
+		for i := 0; i < a.NumField(); i++ {
+			if cfg.ignoreUnexported && a.Type().Field(i).PkgPath != "" {
+				continue
+			}
+
+			afield := a.Field(i)
+			bfield := b.Field(i)
+			if afield.CanAddr() && !afield.CanSet() {
+				afield = exportUnexportedField(afield)
+			}
+			if bfield.CanAddr() && !bfield.CanSet() {
+				bfield = exportUnexportedField(bfield)
+			}
+
+			if strategies[i].omitempty && (afield.IsZero() || bfield.IsZero()) {
+				continue
+			}
+
+			if !performDeepEqual(afield, bfield, cfg, visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		if cfg.ignoreZeroValues && (a.IsZero() || b.IsZero()) {
+			return true
+		}
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
@@ -1,7 +1,10 @@
 package mirror
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"unsafe"
 )
 
@@ -11,6 +14,104 @@ type DeepCopy struct {
 	target           reflect.Value
 	ignoreZeroValues bool
 	ignoreUnexported bool
+	converters       map[converterKey]reflect.Value
+	visited          map[uintptr]reflect.Value
+	deepCopyFuncs    map[reflect.Type]reflect.Value
+	chanPolicy       ChanPolicy
+	funcPolicy       FuncPolicy
+}
+
+// ChanPolicy controls how DeepCopy handles channel-typed values.
+type ChanPolicy int
+
+const (
+	// ChanShare copies the channel reference as-is, so target and source share the same channel.
+	// This is the default, matching the behavior of every other unhandled kind.
+	ChanShare ChanPolicy = iota
+	// ChanNil sets the target channel to nil, dropping the reference entirely.
+	ChanNil
+	// ChanNew allocates a new channel of the same type and buffer size as the source. The new
+	// channel does not share any of the source's pending values.
+	ChanNew
+)
+
+// FuncPolicy controls how DeepCopy handles func-typed values.
+type FuncPolicy int
+
+const (
+	// FuncShare copies the function reference as-is. This is the default, matching the behavior
+	// of every other unhandled kind.
+	FuncShare FuncPolicy = iota
+	// FuncNil sets the target func to nil, dropping the reference entirely.
+	FuncNil
+)
+
+// errType is the reflect.Type of the error interface, used to validate registered deep copy funcs.
+var errType = reflect.TypeOf((*error)(nil)).Elem()
+
+// globalDeepCopyFuncs holds deep copy funcs registered package-wide via RegisterGlobalDeepCopyFunc,
+// consulted by every DeepCopy instance as a fallback after its own instance-local registrations.
+// This is what lets generated code register its fast paths from an init() function, which has no
+// DeepCopy instance of its own to call RegisterDeepCopyFunc on. See cmd/mirror-gen.
+var (
+	globalDeepCopyFuncsMu sync.RWMutex
+	globalDeepCopyFuncs   = map[reflect.Type]reflect.Value{}
+)
+
+// converterKey identifies a registered converter by its source and destination types.
+type converterKey struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// fieldStrategy holds the per-field behavior parsed out of a `mirror:"..."` struct tag.
+// It overrides the global ignoreZeroValues decision and adds a few merge strategies that are
+// otherwise impossible to express with a single package-wide flag.
+type fieldStrategy struct {
+	omitempty  bool // do not overwrite the target field with a zero-value source field.
+	sliceMerge bool // append the source slice's elements to the target slice instead of replacing it.
+	mapMerge   bool // merge the source map's keys into the target map instead of replacing it.
+	keepNeq    bool // skip the field entirely if the target already differs from the source.
+	clearEq    bool // zero the target field if it is equal to the source.
+}
+
+// fieldStrategyCache memoizes the per-field strategies parsed out of a struct type's tags, since
+// reflect.StructTag.Get involves a string scan and struct types are copied repeatedly.
+var fieldStrategyCache sync.Map // map[reflect.Type][]fieldStrategy
+
+// fieldStrategiesFor returns the parsed `mirror` tag strategy for every field of t, in field order.
+func fieldStrategiesFor(t reflect.Type) []fieldStrategy {
+	if cached, ok := fieldStrategyCache.Load(t); ok {
+		return cached.([]fieldStrategy)
+	}
+
+	strategies := make([]fieldStrategy, t.NumField())
+	for i := range strategies {
+		tag, ok := t.Field(i).Tag.Lookup("mirror")
+		if !ok {
+			continue
+		}
+
+		var s fieldStrategy
+		for _, part := range strings.Split(tag, ",") {
+			switch strings.TrimSpace(part) {
+			case "omitempty":
+				s.omitempty = true
+			case "slicemerge":
+				s.sliceMerge = true
+			case "mapmerge":
+				s.mapMerge = true
+			case "keepneq":
+				s.keepNeq = true
+			case "cleareq":
+				s.clearEq = true
+			}
+		}
+		strategies[i] = s
+	}
+
+	stored, _ := fieldStrategyCache.LoadOrStore(t, strategies)
+	return stored.([]fieldStrategy)
 }
 
 // DeepCopyInto creates a new DeepCopy configured to copy into the target object.
@@ -42,10 +143,97 @@ func (d *DeepCopy) SetIgnoreUnexported(ignoreUnexported bool) *DeepCopy {
 	return d
 }
 
+// SetChanPolicy sets how this DeepCopy should handle channel-typed values. The default is ChanShare.
+func (d *DeepCopy) SetChanPolicy(chanPolicy ChanPolicy) *DeepCopy {
+	d.chanPolicy = chanPolicy
+	return d
+}
+
+// SetFuncPolicy sets how this DeepCopy should handle func-typed values. The default is FuncShare.
+func (d *DeepCopy) SetFuncPolicy(funcPolicy FuncPolicy) *DeepCopy {
+	d.funcPolicy = funcPolicy
+	return d
+}
+
+// RegisterConverter registers a function used to convert a value of srcType into a value of dstType.
+// Converters are consulted before the default kind-based copy logic, which allows cross-type deep
+// copies (for example time.Time to string, or int to a named enum type) that would otherwise be
+// rejected by From's same-type requirement. srcType and dstType are only used to determine their
+// reflect.Type; their values are otherwise ignored.
+func (d *DeepCopy) RegisterConverter(srcType, dstType interface{}, fn func(src interface{}) (interface{}, error)) *DeepCopy {
+	if d.converters == nil {
+		d.converters = make(map[converterKey]reflect.Value)
+	}
+	key := converterKey{src: reflect.TypeOf(srcType), dst: reflect.TypeOf(dstType)}
+	d.converters[key] = reflect.ValueOf(fn)
+	return d
+}
+
+// RegisterDeepCopyFunc registers fn as a fast-path deep copy implementation for its input type,
+// scoped to this DeepCopy instance. fn must have the signature func(in T, out *T) error, in the
+// style of Kubernetes' generated DeepCopyInto methods. When set, it is consulted before falling
+// back to the reflect-based struct/slice/map walk, which is roughly an order of magnitude slower
+// for hot types. Use RegisterGlobalDeepCopyFunc to register a func for every DeepCopy instance,
+// such as from generated code.
+func (d *DeepCopy) RegisterDeepCopyFunc(fn interface{}) error {
+	fval, typ, err := validateDeepCopyFunc(fn)
+	if err != nil {
+		return err
+	}
+	if d.deepCopyFuncs == nil {
+		d.deepCopyFuncs = make(map[reflect.Type]reflect.Value)
+	}
+	d.deepCopyFuncs[typ] = fval
+	return nil
+}
+
+// RegisterGlobalDeepCopyFunc registers fn, with the same signature requirements as
+// RegisterDeepCopyFunc, as the fast-path deep copy implementation used by every DeepCopy instance.
+// It is safe to call concurrently and is intended to be called from an init() function, which is
+// what cmd/mirror-gen emits for each type it generates a DeepCopyInto method for.
+func RegisterGlobalDeepCopyFunc(fn interface{}) error {
+	fval, typ, err := validateDeepCopyFunc(fn)
+	if err != nil {
+		return err
+	}
+	globalDeepCopyFuncsMu.Lock()
+	globalDeepCopyFuncs[typ] = fval
+	globalDeepCopyFuncsMu.Unlock()
+	return nil
+}
+
+// validateDeepCopyFunc checks that fn has the signature func(in T, out *T) error and returns its
+// reflect.Value along with T.
+func validateDeepCopyFunc(fn interface{}) (reflect.Value, reflect.Type, error) {
+	fval := reflect.ValueOf(fn)
+	if !fval.IsValid() {
+		return reflect.Value{}, nil, fmt.Errorf("mirror: deep copy func must have signature func(in T, out *T) error, got nil")
+	}
+	ftype := fval.Type()
+	if ftype.Kind() != reflect.Func || ftype.NumIn() != 2 || ftype.NumOut() != 1 ||
+		ftype.In(1).Kind() != reflect.Ptr || ftype.In(1).Elem() != ftype.In(0) || ftype.Out(0) != errType {
+		return reflect.Value{}, nil, fmt.Errorf("mirror: deep copy func must have signature func(in T, out *T) error, got %s", ftype)
+	}
+	return fval, ftype.In(0), nil
+}
+
 // From performs a deep copy of the src object into the target object.
-// The src object must be of the same type as the target object. It does not have to be a pointer but it
-// can be. The src object will not be mutated.
+// The src object must be of the same type as the target object, unless a registered converter
+// (see RegisterConverter) bridges the difference somewhere in the object graph, down to a single
+// mismatched field. It does not have to be a pointer but it can be. The src object will not be mutated.
+//
+// From panics if a registered converter returns an error, or if src and target disagree on a type
+// with no converter to bridge it. Use FromE to handle the error instead.
 func (d *DeepCopy) From(src interface{}) {
+	if err := d.FromE(src); err != nil {
+		panic(err)
+	}
+}
+
+// FromE performs a deep copy of the src object into the target object, like From, but returns any
+// error produced by a registered converter, or by a type mismatch with no converter to bridge it,
+// instead of panicking.
+func (d *DeepCopy) FromE(src interface{}) error {
 	sval := reflect.ValueOf(src)
 
 	// Cast T to *T
@@ -54,41 +242,93 @@ func (d *DeepCopy) From(src interface{}) {
 		temp.Elem().Set(sval)
 		sval = temp
 	}
-	if d.target.Type() != sval.Type() {
-		panic("different types between target and source")
-	}
 
-	d.performDeepCopy(d.target, sval)
+	// Reset the visited set for this invocation so that pointers, slices and maps revisited
+	// while copying this object graph are detected and reused instead of recursed into again.
+	// This is what keeps cyclic structures (e.g. doubly-linked lists) from overflowing the stack.
+	d.visited = make(map[uintptr]reflect.Value)
+
+	// Whether src and target agree on every type is left to performDeepCopy to decide node by
+	// node: a converter registered for the outer pair, or for a single mismatched field deep
+	// inside an otherwise identical struct, are handled identically by the recursive walk.
+	return d.performDeepCopy(d.target, sval)
 }
 
-func (d *DeepCopy) performDeepCopy(target reflect.Value, src reflect.Value) {
+func (d *DeepCopy) performDeepCopy(target reflect.Value, src reflect.Value) error {
 	// fmt.Println("target", target.Type(), target, target.CanSet(), target.CanAddr())
 	// fmt.Println("source", src.Type(), src)
 
+	if src.IsValid() && target.IsValid() && target.CanAddr() {
+		if fn, ok := d.deepCopyFuncs[src.Type()]; ok {
+			return callDeepCopyFunc(fn, target, src)
+		}
+		globalDeepCopyFuncsMu.RLock()
+		fn, ok := globalDeepCopyFuncs[src.Type()]
+		globalDeepCopyFuncsMu.RUnlock()
+		if ok {
+			return callDeepCopyFunc(fn, target, src)
+		}
+	}
+
+	if src.IsValid() && target.IsValid() {
+		if conv, ok := d.converters[converterKey{src: src.Type(), dst: target.Type()}]; ok {
+			out := conv.Call([]reflect.Value{src})
+			result, err := out[0], out[1]
+			if !err.IsNil() {
+				return err.Interface().(error)
+			}
+			resultVal := reflect.ValueOf(result.Interface())
+			if !resultVal.Type().AssignableTo(target.Type()) {
+				return fmt.Errorf("mirror: converter for %s -> %s returned %s, not %s", src.Type(), target.Type(), resultVal.Type(), target.Type())
+			}
+			target.Set(resultVal)
+			return nil
+		}
+
+		// No converter bridges this node. A Kind mismatch below would otherwise panic deep inside
+		// reflect (Field on a non-struct, Set with an unassignable type, ...); report it cleanly
+		// instead. src.Kind() == Invalid is excluded: that's how a nil source pointer's Elem() is
+		// threaded through on purpose, regardless of what target points to.
+		if src.Kind() != reflect.Invalid && target.Kind() != src.Kind() {
+			return fmt.Errorf("mirror: cannot copy %s into %s: no converter registered for this type pair", src.Type(), target.Type())
+		}
+	}
+
 	switch src.Kind() {
 	case reflect.Invalid:
 	case reflect.Array:
 		length := src.Len()
 		for i := 0; i < length; i++ {
-			d.performDeepCopy(target.Index(i), src.Index(i))
+			if err := d.performDeepCopy(target.Index(i), src.Index(i)); err != nil {
+				return err
+			}
 		}
 	case reflect.Slice:
 		if src.IsNil() {
 			if !d.ignoreZeroValues {
 				target.Set(reflect.Zero(src.Type()))
 			}
-			return
+			return nil
+		}
+		if existing, ok := d.visited[src.Pointer()]; ok {
+			target.Set(existing)
+			return nil
 		}
 		length := src.Len()
 		tlength := target.Len()
 		newSlice := reflect.MakeSlice(src.Type(), length, src.Cap())
+		d.visited[src.Pointer()] = newSlice
 		for i := 0; i < length; i++ {
 			// newSlice.Index(i) will be initialized to a zero value. We must first copy the target into it
 			// before copying the source.
 			if i < tlength {
-				d.performDeepCopy(newSlice.Index(i), target.Index(i))
+				if err := d.performDeepCopy(newSlice.Index(i), target.Index(i)); err != nil {
+					return err
+				}
+			}
+			if err := d.performDeepCopy(newSlice.Index(i), src.Index(i)); err != nil {
+				return err
 			}
-			d.performDeepCopy(newSlice.Index(i), src.Index(i))
 		}
 		target.Set(newSlice)
 	case reflect.Map:
@@ -96,9 +336,14 @@ func (d *DeepCopy) performDeepCopy(target reflect.Value, src reflect.Value) {
 			if !d.ignoreZeroValues {
 				target.Set(reflect.Zero(src.Type()))
 			}
-			return
+			return nil
+		}
+		if existing, ok := d.visited[src.Pointer()]; ok {
+			target.Set(existing)
+			return nil
 		}
 		newMap := reflect.MakeMapWithSize(src.Type(), src.Len())
+		d.visited[src.Pointer()] = newMap
 		iter := src.MapRange()
 		for iter.Next() {
 			// In order to copy the map item properly, we create a new zero value item.
@@ -110,15 +355,20 @@ func (d *DeepCopy) performDeepCopy(target reflect.Value, src reflect.Value) {
 
 			if targetVal.IsValid() {
 				// Value must be addressable in order to unexport the field, so we put the value in a pointer.
-				d.performDeepCopy(newVal, targetVal)
+				if err := d.performDeepCopy(newVal, targetVal); err != nil {
+					return err
+				}
 			}
 			// Value must be addressable in order to unexport the field, so we put the value in a pointer.
-			d.performDeepCopy(newVal, srcVal)
+			if err := d.performDeepCopy(newVal, srcVal); err != nil {
+				return err
+			}
 
 			newMap.SetMapIndex(iter.Key(), newVal)
 		}
 		target.Set(newMap)
 	case reflect.Struct:
+		strategies := fieldStrategiesFor(src.Type())
 		length := src.NumField()
 		for i := 0; i < length; i++ {
 			tfield := target.Field(i)
@@ -132,20 +382,190 @@ func (d *DeepCopy) performDeepCopy(target reflect.Value, src reflect.Value) {
 				sfield = exportUnexportedField(sfield)
 			}
 
-			d.performDeepCopy(tfield, sfield)
+			strat := strategies[i]
+
+			if strat.keepNeq && !reflect.DeepEqual(tfield.Interface(), sfield.Interface()) {
+				continue
+			}
+			if strat.clearEq && reflect.DeepEqual(tfield.Interface(), sfield.Interface()) {
+				tfield.Set(reflect.Zero(tfield.Type()))
+				continue
+			}
+			if strat.sliceMerge && sfield.Kind() == reflect.Slice {
+				if err := d.mergeSlice(tfield, sfield); err != nil {
+					return err
+				}
+				continue
+			}
+			if strat.mapMerge && sfield.Kind() == reflect.Map {
+				if err := d.mergeMap(tfield, sfield); err != nil {
+					return err
+				}
+				continue
+			}
+			if strat.omitempty {
+				prevIgnoreZeroValues := d.ignoreZeroValues
+				d.ignoreZeroValues = true
+				err := d.performDeepCopy(tfield, sfield)
+				d.ignoreZeroValues = prevIgnoreZeroValues
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := d.performDeepCopy(tfield, sfield); err != nil {
+				return err
+			}
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			if !d.ignoreZeroValues {
+				target.Set(reflect.Zero(target.Type()))
+			}
+			return nil
+		}
+		// The interface's dynamic value is not addressable, so it must be made addressable first to
+		// permit unexporting its fields, the same way map values are handled above.
+		concrete := makeAddressable(src.Elem())
+		newVal := reflect.New(concrete.Type()).Elem()
+		if err := d.performDeepCopy(newVal, concrete); err != nil {
+			return err
+		}
+		target.Set(newVal)
+	case reflect.Chan:
+		switch d.chanPolicy {
+		case ChanNil:
+			target.Set(reflect.Zero(src.Type()))
+		case ChanNew:
+			if src.IsNil() {
+				if !d.ignoreZeroValues {
+					target.Set(reflect.Zero(src.Type()))
+				}
+			} else {
+				target.Set(reflect.MakeChan(src.Type(), src.Cap()))
+			}
+		default: // ChanShare
+			if src.IsNil() {
+				if !d.ignoreZeroValues {
+					target.Set(reflect.Zero(src.Type()))
+				}
+			} else {
+				target.Set(src)
+			}
+		}
+	case reflect.Func:
+		switch d.funcPolicy {
+		case FuncNil:
+			target.Set(reflect.Zero(src.Type()))
+		default: // FuncShare
+			if src.IsNil() {
+				if !d.ignoreZeroValues {
+					target.Set(reflect.Zero(src.Type()))
+				}
+			} else {
+				target.Set(src)
+			}
 		}
 	case reflect.Ptr:
-		if target.IsNil() && !src.IsNil() {
+		if src.IsNil() {
+			return d.performDeepCopy(target.Elem(), src.Elem())
+		}
+		if existing, ok := d.visited[src.Pointer()]; ok {
+			target.Set(existing)
+			return nil
+		}
+		if target.IsNil() {
 			// If target is a nil pointer and src is not nil, create a zeroed object for target.
 			target.Set(reflect.New(target.Type().Elem()))
 		}
-		d.performDeepCopy(target.Elem(), src.Elem())
+		// Record the target before recursing so that a cycle back to this same source pointer
+		// reuses it instead of recursing forever.
+		d.visited[src.Pointer()] = target
+		return d.performDeepCopy(target.Elem(), src.Elem())
 	default:
 		// Should always be settable.
 		if !(d.ignoreZeroValues && src.IsZero()) {
 			target.Set(src)
 		}
 	}
+	return nil
+}
+
+// callDeepCopyFunc invokes a registered deep copy func of signature func(in T, out *T) error.
+func callDeepCopyFunc(fn reflect.Value, target, src reflect.Value) error {
+	out := fn.Call([]reflect.Value{src, target.Addr()})
+	if !out[0].IsNil() {
+		return out[0].Interface().(error)
+	}
+	return nil
+}
+
+// mergeSlice implements the "slicemerge" field strategy: it appends src's elements after target's
+// existing elements instead of replacing target outright.
+func (d *DeepCopy) mergeSlice(target, src reflect.Value) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	tlength := 0
+	if !target.IsNil() {
+		tlength = target.Len()
+	}
+
+	merged := reflect.MakeSlice(src.Type(), tlength+src.Len(), tlength+src.Len())
+	for i := 0; i < tlength; i++ {
+		if err := d.performDeepCopy(merged.Index(i), target.Index(i)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < src.Len(); i++ {
+		if err := d.performDeepCopy(merged.Index(tlength+i), src.Index(i)); err != nil {
+			return err
+		}
+	}
+	target.Set(merged)
+	return nil
+}
+
+// mergeMap implements the "mapmerge" field strategy: it merges src's keys into target's existing
+// keys instead of replacing target outright, with src taking precedence on key collisions.
+func (d *DeepCopy) mergeMap(target, src reflect.Value) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	var merged reflect.Value
+	if !target.IsNil() {
+		merged = reflect.MakeMapWithSize(src.Type(), target.Len()+src.Len())
+		iter := target.MapRange()
+		for iter.Next() {
+			newVal := reflect.New(src.Type().Elem()).Elem()
+			if err := d.performDeepCopy(newVal, makeAddressable(iter.Value())); err != nil {
+				return err
+			}
+			merged.SetMapIndex(iter.Key(), newVal)
+		}
+	} else {
+		merged = reflect.MakeMapWithSize(src.Type(), src.Len())
+	}
+
+	iter := src.MapRange()
+	for iter.Next() {
+		newVal := reflect.New(src.Type().Elem()).Elem()
+		existingVal := makeAddressable(merged.MapIndex(iter.Key()))
+		if existingVal.IsValid() {
+			if err := d.performDeepCopy(newVal, existingVal); err != nil {
+				return err
+			}
+		}
+		if err := d.performDeepCopy(newVal, makeAddressable(iter.Value())); err != nil {
+			return err
+		}
+		merged.SetMapIndex(iter.Key(), newVal)
+	}
+	target.Set(merged)
+	return nil
 }
 
 // makeAddressable will make a value addressible if it is not by creating a pointer and copying the value into
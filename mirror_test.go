@@ -1,6 +1,8 @@
 package mirror
 
 import (
+	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -157,6 +159,420 @@ func TestPrivateFieldsInSlice(t *testing.T) {
 	assert.Equal(t, int64(10), target[0].d)
 }
 
+func TestCircularReference(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	a := &node{Name: "a"}
+	b := &node{Name: "b", Next: a}
+	a.Next = b
+
+	target := &node{}
+	DeepCopyInto(target).From(a)
+
+	assert.Equal(t, "a", target.Name)
+	assert.Equal(t, "b", target.Next.Name)
+	assert.Same(t, target, target.Next.Next)
+}
+
+func TestFieldTagOmitempty(t *testing.T) {
+	type bax struct {
+		Name string `mirror:"omitempty"`
+		Age  int
+	}
+
+	target := bax{Name: "original", Age: 1}
+	DeepCopyInto(&target).From(bax{Name: "", Age: 2})
+
+	assert.Equal(t, "original", target.Name)
+	assert.Equal(t, 2, target.Age)
+}
+
+func TestFieldTagSliceMerge(t *testing.T) {
+	type bax struct {
+		Items []int `mirror:"slicemerge"`
+	}
+
+	target := bax{Items: []int{1, 2}}
+	DeepCopyInto(&target).From(bax{Items: []int{3, 4}})
+
+	assert.Equal(t, []int{1, 2, 3, 4}, target.Items)
+}
+
+func TestFieldTagMapMerge(t *testing.T) {
+	type bax struct {
+		Items map[string]int `mirror:"mapmerge"`
+	}
+
+	target := bax{Items: map[string]int{"a": 1}}
+	DeepCopyInto(&target).From(bax{Items: map[string]int{"b": 2}})
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, target.Items)
+}
+
+func TestFieldTagKeepNeq(t *testing.T) {
+	type bax struct {
+		Name string `mirror:"keepneq"`
+	}
+
+	target := bax{Name: "modified"}
+	DeepCopyInto(&target).From(bax{Name: "source"})
+
+	assert.Equal(t, "modified", target.Name)
+}
+
+func TestFieldTagClearEq(t *testing.T) {
+	type bax struct {
+		Name string `mirror:"cleareq"`
+	}
+
+	target := bax{Name: "same"}
+	DeepCopyInto(&target).From(bax{Name: "same"})
+
+	assert.Equal(t, "", target.Name)
+}
+
+func TestInterfaceField(t *testing.T) {
+	type bax struct {
+		G string
+	}
+	type foo struct {
+		X interface{}
+	}
+
+	target := foo{}
+	src := foo{X: bax{G: "hello"}}
+
+	DeepCopyInto(&target).From(src)
+
+	assert.Equal(t, bax{G: "hello"}, target.X)
+}
+
+func TestInterfaceIgnoreZero(t *testing.T) {
+	type bax struct {
+		V interface{}
+	}
+
+	target := bax{V: "existing"}
+	DeepCopyInto(&target).
+		SetIgnoreZeroValues(true).
+		From(bax{V: nil})
+
+	assert.Equal(t, "existing", target.V)
+}
+
+func TestChanPolicy(t *testing.T) {
+	type bax struct {
+		Ch chan int
+	}
+
+	ch := make(chan int, 3)
+
+	target := bax{}
+	DeepCopyInto(&target).From(bax{Ch: ch})
+	assert.Equal(t, ch, target.Ch)
+
+	target = bax{}
+	DeepCopyInto(&target).SetChanPolicy(ChanNil).From(bax{Ch: ch})
+	assert.Nil(t, target.Ch)
+
+	target = bax{}
+	DeepCopyInto(&target).SetChanPolicy(ChanNew).From(bax{Ch: ch})
+	assert.NotNil(t, target.Ch)
+	assert.NotEqual(t, ch, target.Ch)
+	assert.Equal(t, cap(ch), cap(target.Ch))
+}
+
+func TestFuncPolicy(t *testing.T) {
+	type bax struct {
+		Fn func() int
+	}
+
+	fn := func() int { return 42 }
+
+	target := bax{}
+	DeepCopyInto(&target).From(bax{Fn: fn})
+	assert.Equal(t, 42, target.Fn())
+
+	target = bax{}
+	DeepCopyInto(&target).SetFuncPolicy(FuncNil).From(bax{Fn: fn})
+	assert.Nil(t, target.Fn)
+}
+
+func TestChanFuncIgnoreZero(t *testing.T) {
+	type bax struct {
+		Ch chan int
+		Fn func() int
+	}
+
+	ch := make(chan int)
+	fn := func() int { return 42 }
+
+	target := bax{Ch: ch, Fn: fn}
+	DeepCopyInto(&target).
+		SetIgnoreZeroValues(true).
+		From(bax{Ch: nil, Fn: nil})
+
+	assert.Equal(t, ch, target.Ch)
+	assert.Equal(t, 42, target.Fn())
+}
+
+func TestClone(t *testing.T) {
+	type bax struct {
+		Items []int
+	}
+
+	source := bax{Items: []int{1, 2, 3}}
+	cloned := Clone(source)
+
+	assert.Equal(t, source, cloned)
+
+	cloned.Items[0] = 99
+	assert.Equal(t, 1, source.Items[0])
+}
+
+func TestDeepEqual(t *testing.T) {
+	type bax struct {
+		Items []int
+	}
+
+	a := bax{Items: []int{1, 2, 3}}
+	b := bax{Items: []int{1, 2, 3}}
+	c := bax{Items: []int{1, 2, 4}}
+
+	assert.True(t, DeepEqual(a, b))
+	assert.False(t, DeepEqual(a, c))
+}
+
+func TestDeepEqualIgnoreZeroValues(t *testing.T) {
+	type bax struct {
+		Items []int
+	}
+
+	target := bax{Items: []int{1, 2, 3}}
+	source := bax{Items: nil}
+
+	DeepCopyInto(&target).SetIgnoreZeroValues(true).From(source)
+
+	assert.False(t, DeepEqual(target, source))
+	assert.True(t, DeepEqual(target, source, EqualIgnoreZeroValues(true)))
+}
+
+func TestDeepEqualIgnoreZeroValuesScalarField(t *testing.T) {
+	type bax struct {
+		Name string
+	}
+
+	target := bax{Name: "original"}
+	source := bax{Name: ""}
+
+	DeepCopyInto(&target).SetIgnoreZeroValues(true).From(source)
+
+	assert.False(t, DeepEqual(target, source))
+	assert.True(t, DeepEqual(target, source, EqualIgnoreZeroValues(true)))
+}
+
+func TestDeepEqualIgnoreZeroValuesInterfaceField(t *testing.T) {
+	type bax struct {
+		V interface{}
+	}
+
+	target := bax{V: "existing"}
+	source := bax{V: nil}
+
+	DeepCopyInto(&target).SetIgnoreZeroValues(true).From(source)
+
+	assert.False(t, DeepEqual(target, source))
+	assert.True(t, DeepEqual(target, source, EqualIgnoreZeroValues(true)))
+}
+
+func TestDeepEqualCircularReference(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+
+	a := &node{Name: "a"}
+	b := &node{Name: "b", Next: a}
+	a.Next = b
+
+	clone := &node{}
+	DeepCopyInto(clone).From(a)
+
+	assert.True(t, DeepEqual(a, clone))
+}
+
+func TestDeepEqualConverters(t *testing.T) {
+	type foo struct {
+		Count int
+	}
+	type bax struct {
+		Count string
+	}
+
+	d := DeepCopyInto(&bax{}).
+		RegisterConverter(foo{}, bax{}, func(src interface{}) (interface{}, error) {
+			return bax{Count: strconv.Itoa(src.(foo).Count)}, nil
+		})
+
+	assert.True(t, DeepEqual(foo{Count: 42}, bax{Count: "42"}, EqualConverters(d)))
+	assert.False(t, DeepEqual(foo{Count: 42}, bax{Count: "41"}, EqualConverters(d)))
+}
+
+func TestDeepEqualConverterOnNestedField(t *testing.T) {
+	type foo struct {
+		Name      string
+		CreatedAt int
+	}
+	type bax struct {
+		Name      string
+		CreatedAt string
+	}
+
+	d := DeepCopyInto(&bax{}).
+		RegisterConverter(0, "", func(src interface{}) (interface{}, error) {
+			return strconv.Itoa(src.(int)), nil
+		})
+
+	assert.True(t, DeepEqual(foo{Name: "hi", CreatedAt: 42}, bax{Name: "hi", CreatedAt: "42"}, EqualConverters(d)))
+	assert.False(t, DeepEqual(foo{Name: "hi", CreatedAt: 42}, bax{Name: "hi", CreatedAt: "41"}, EqualConverters(d)))
+	assert.False(t, DeepEqual(foo{Name: "bye", CreatedAt: 42}, bax{Name: "hi", CreatedAt: "42"}, EqualConverters(d)))
+}
+
+func TestRegisterDeepCopyFunc(t *testing.T) {
+	type bax struct {
+		Name string
+	}
+	type foo struct {
+		Bax bax
+	}
+
+	calls := 0
+	target := foo{}
+	source := foo{Bax: bax{Name: "hello"}}
+
+	d := DeepCopyInto(&target)
+	err := d.RegisterDeepCopyFunc(func(in bax, out *bax) error {
+		calls++
+		out.Name = in.Name
+		return nil
+	})
+	assert.NoError(t, err)
+
+	d.From(source)
+
+	assert.Equal(t, "hello", target.Bax.Name)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRegisterDeepCopyFuncInvalidSignature(t *testing.T) {
+	err := DeepCopyInto(&struct{}{}).RegisterDeepCopyFunc(func(in string) string { return in })
+	assert.Error(t, err)
+}
+
+func TestRegisterDeepCopyFuncNil(t *testing.T) {
+	err := DeepCopyInto(&struct{}{}).RegisterDeepCopyFunc(nil)
+	assert.Error(t, err)
+}
+
+func TestConverter(t *testing.T) {
+	type foo struct {
+		Count int
+	}
+	type bax struct {
+		Count string
+	}
+
+	target := bax{}
+	source := foo{Count: 42}
+
+	DeepCopyInto(&target).
+		RegisterConverter(foo{}, bax{}, func(src interface{}) (interface{}, error) {
+			return bax{Count: strconv.Itoa(src.(foo).Count)}, nil
+		}).
+		From(source)
+
+	assert.Equal(t, "42", target.Count)
+}
+
+func TestConverterError(t *testing.T) {
+	type foo struct {
+		Count int
+	}
+	type bax struct {
+		Count string
+	}
+
+	target := bax{}
+	source := foo{Count: 42}
+
+	err := DeepCopyInto(&target).
+		RegisterConverter(foo{}, bax{}, func(src interface{}) (interface{}, error) {
+			return nil, errors.New("conversion failed")
+		}).
+		FromE(source)
+
+	assert.EqualError(t, err, "conversion failed")
+}
+
+func TestConverterWrongReturnType(t *testing.T) {
+	type foo struct {
+		Count int
+	}
+	type bax struct {
+		Count string
+	}
+
+	target := bax{}
+	source := foo{Count: 42}
+
+	err := DeepCopyInto(&target).
+		RegisterConverter(foo{}, bax{}, func(src interface{}) (interface{}, error) {
+			return src.(foo).Count, nil
+		}).
+		FromE(source)
+
+	assert.Error(t, err)
+}
+
+func TestConverterOnNestedField(t *testing.T) {
+	type foo struct {
+		Name      string
+		CreatedAt int
+	}
+	type bax struct {
+		Name      string
+		CreatedAt string
+	}
+
+	target := bax{}
+	source := foo{Name: "hello", CreatedAt: 42}
+
+	DeepCopyInto(&target).
+		RegisterConverter(0, "", func(src interface{}) (interface{}, error) {
+			return strconv.Itoa(src.(int)), nil
+		}).
+		From(source)
+
+	assert.Equal(t, "hello", target.Name)
+	assert.Equal(t, "42", target.CreatedAt)
+}
+
+func TestFromETypeMismatchNoConverter(t *testing.T) {
+	type foo struct {
+		Count string
+	}
+	type bax struct {
+		Count int
+	}
+
+	err := DeepCopyInto(&bax{}).FromE(foo{Count: "hello"})
+
+	assert.Error(t, err)
+}
+
 func TestPrivateFieldsInMap(t *testing.T) {
 
 	type Quantity struct {